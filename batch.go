@@ -0,0 +1,168 @@
+package mdbm
+
+/*
+
+#include "mdbm.h"
+
+*/
+import "C"
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// DefaultMaxBatchSize is the number of buffered operations that forces an
+// immediate flush of a coalesced batch (see CoalesceBatch).
+var DefaultMaxBatchSize = 1000
+
+// DefaultMaxBatchDelay is how long a coalesced batch waits to accumulate
+// further concurrent submissions before flushing.
+var DefaultMaxBatchDelay = 10 * time.Millisecond
+
+// KV is a single key-value pair, used by Batch and CoalesceBatch.
+type KV struct {
+	Key []byte
+	Val []byte
+}
+
+type batchOp struct {
+	key    []byte
+	val    []byte
+	delete bool
+}
+
+// Batch buffers a sequence of Put/Delete operations and applies them under
+// a single lock/unlock pass on Commit, avoiding the per-call lock overhead
+// of repeated calls to Put/Delete.
+type Batch struct {
+	db  *MDBM
+	ops []batchOp
+}
+
+// NewBatch creates a Batch bound to db.
+func (db *MDBM) NewBatch() *Batch {
+	return &Batch{db: db}
+}
+
+// Put buffers a key-value store operation.
+func (b *Batch) Put(key []byte, val []byte) {
+	b.ops = append(b.ops, batchOp{key: key, val: val})
+}
+
+// Delete buffers a delete operation for key.
+func (b *Batch) Delete(key []byte) {
+	b.ops = append(b.ops, batchOp{key: key, delete: true})
+}
+
+// Commit applies all buffered operations under a single lock.
+func (b *Batch) Commit() error {
+	return b.db.applyBatch(b.ops)
+}
+
+// Batch stores entries under a single lock/unlock pass, avoiding the
+// per-call lock overhead of calling Put in a loop.
+func (db *MDBM) Batch(entries []KV) error {
+	ops := make([]batchOp, len(entries))
+	for i, kv := range entries {
+		ops[i] = batchOp{key: kv.Key, val: kv.Val}
+	}
+	return db.applyBatch(ops)
+}
+
+func (db *MDBM) applyBatch(ops []batchOp) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	C.mdbm_lock(db.dbh)
+	defer C.mdbm_unlock(db.dbh)
+
+	for _, op := range ops {
+		var k C.datum
+		k.dptr = (*C.char)(unsafe.Pointer(&op.key[0]))
+		k.dsize = C.int(len(op.key))
+
+		if op.delete {
+			if _, e := C.mdbm_delete(db.dbh, k); e != nil {
+				return errors.New("Cannot delete entry in batch: " + e.Error())
+			}
+			continue
+		}
+
+		var v C.datum
+		v.dptr = (*C.char)(unsafe.Pointer(&op.val[0]))
+		v.dsize = C.int(len(op.val))
+		if _, e := C.mdbm_store(db.dbh, k, v, C.MDBM_REPLACE); e != nil {
+			return errors.New("Cannot store entry in batch: " + e.Error())
+		}
+	}
+	return nil
+}
+
+// pendingBatch accumulates ops from concurrent CoalesceBatch callers until
+// it is flushed by size or by its timer.
+type pendingBatch struct {
+	db    *MDBM
+	ops   []batchOp
+	done  []chan error
+	timer *time.Timer
+
+	flushOnce sync.Once
+}
+
+// flush applies the batch's buffered ops and hands each waiter its error.
+// db.batch is cleared first so a new pendingBatch is started for the next
+// caller. The size threshold in CoalesceBatch stops the timer before
+// calling flush, but Timer.Stop does not guarantee the AfterFunc goroutine
+// hasn't already fired, so flushOnce guards against both triggers running
+// the body concurrently and double-sending on pb.done.
+func (pb *pendingBatch) flush() {
+	pb.flushOnce.Do(func() {
+		pb.db.batchMu.Lock()
+		if pb.db.batch == pb {
+			pb.db.batch = nil
+		}
+		pb.db.batchMu.Unlock()
+
+		err := pb.db.applyBatch(pb.ops)
+		for _, c := range pb.done {
+			c <- err
+		}
+	})
+}
+
+// CoalesceBatch merges concurrent CoalesceBatch submissions into a single
+// flush, applied after DefaultMaxBatchDelay or once DefaultMaxBatchSize
+// operations have accumulated, whichever comes first. This is modeled on
+// Bolt's DB.Batch and amortizes lock/unlock cost across callers.
+func (db *MDBM) CoalesceBatch(entries []KV) error {
+	ops := make([]batchOp, len(entries))
+	for i, kv := range entries {
+		ops[i] = batchOp{key: kv.Key, val: kv.Val}
+	}
+
+	errc := make(chan error, 1)
+
+	db.batchMu.Lock()
+	if db.batch == nil {
+		db.batch = &pendingBatch{db: db}
+		db.batch.timer = time.AfterFunc(DefaultMaxBatchDelay, db.batch.flush)
+	}
+	pb := db.batch
+	pb.ops = append(pb.ops, ops...)
+	pb.done = append(pb.done, errc)
+	flushNow := len(pb.ops) >= DefaultMaxBatchSize
+	if flushNow {
+		pb.timer.Stop()
+		db.batch = nil
+	}
+	db.batchMu.Unlock()
+
+	if flushNow {
+		go pb.flush()
+	}
+
+	return <-errc
+}
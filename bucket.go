@@ -0,0 +1,215 @@
+package mdbm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// metaKeyPrefix marks the reserved keys that store the name -> id mapping
+// for every bucket. It never collides with a real bucket's prefix since
+// bucket ids are assigned starting at 1 and are 4 bytes, same width as
+// this prefix, but buckets are looked up through Bucket/CreateBucket
+// rather than by raw prefix match.
+var metaKeyPrefix = []byte{0xff, 0xff, 0xff, 0xff}
+
+// bucketCounterKey stores the next unused bucket id. It uses a prefix
+// distinct from metaKeyPrefix so it can never equal metaKey(name) for any
+// bucket name, including a bucket literally named "__next_id" — if it
+// shared metaKeyPrefix, CreateBucket's own write of the allocated id would
+// clobber the counter (or vice versa), corrupting both.
+var bucketCounterKey = []byte{0xff, 0xff, 0xff, 0xfe}
+
+// Bucket is a logical key space within a single MDBM file. Keys written
+// through a Bucket are transparently prefixed with a compact numeric id, so
+// multiple buckets can coexist without callers hand-rolling key prefixes.
+type Bucket struct {
+	db *MDBM
+	id uint32
+}
+
+func bucketPrefix(id uint32) []byte {
+	p := make([]byte, 4)
+	binary.BigEndian.PutUint32(p, id)
+	return p
+}
+
+func prefixKey(id uint32, key []byte) []byte {
+	return append(bucketPrefix(id), key...)
+}
+
+func metaKey(name []byte) []byte {
+	return append(append([]byte{}, metaKeyPrefix...), name...)
+}
+
+// nextBucketID returns the next unused bucket id, persisted under a
+// reserved meta key so ids survive restarts. It must be called from
+// within an Update transaction: the read-modify-write of the counter is
+// only atomic because tx already holds the DB's exclusive lock for its
+// whole duration.
+func (tx *Tx) nextBucketID() (uint32, error) {
+	val, err := tx.Get(bucketCounterKey)
+	var next uint32 = 1
+	if err == nil && len(val) == 4 {
+		next = binary.BigEndian.Uint32(val)
+	}
+
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, next+1)
+	if err := tx.Put(bucketCounterKey, buf); err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+// CreateBucket creates a new bucket named name and persists its id under a
+// reserved meta key so it can be found again with Bucket. It returns an
+// error if a bucket with that name already exists. The existence check, id
+// allocation, and meta write all happen under a single Update transaction,
+// so concurrent CreateBucket calls can't hand out the same id or silently
+// merge two buckets' keyspaces.
+func (db *MDBM) CreateBucket(name []byte) (*Bucket, error) {
+	mk := metaKey(name)
+
+	var b *Bucket
+	err := db.Update(func(tx *Tx) error {
+		if _, err := tx.Get(mk); err == nil {
+			return errors.New("bucket already exists")
+		}
+
+		id, err := tx.nextBucketID()
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Put(mk, bucketPrefix(id)); err != nil {
+			return err
+		}
+		b = &Bucket{db: db, id: id}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Bucket returns the bucket named name, or nil if it has not been created.
+func (db *MDBM) Bucket(name []byte) *Bucket {
+	val, err := db.Get(metaKey(name))
+	if err != nil || len(val) != 4 {
+		return nil
+	}
+	return &Bucket{db: db, id: binary.BigEndian.Uint32(val)}
+}
+
+// DeleteBucket removes every key in the named bucket along with its meta
+// entry. The scan and delete happen under a single Update transaction.
+func (db *MDBM) DeleteBucket(name []byte) error {
+	b := db.Bucket(name)
+	if b == nil {
+		return errors.New("bucket does not exist")
+	}
+
+	return db.Update(func(tx *Tx) error {
+		var keys [][]byte
+		if err := tx.ForEach(func(k, v []byte) error {
+			if bytes.HasPrefix(k, bucketPrefix(b.id)) {
+				keys = append(keys, append([]byte{}, k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			if err := tx.Delete(k); err != nil {
+				return err
+			}
+		}
+		return tx.Delete(metaKey(name))
+	})
+}
+
+// ForEachBucket calls fn with the name of every bucket created with
+// CreateBucket.
+func (db *MDBM) ForEachBucket(fn func(name []byte) error) error {
+	c, err := db.NewCursor()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	for c.Next() {
+		k := c.Key()
+		if !bytes.HasPrefix(k, metaKeyPrefix) {
+			continue
+		}
+		name := k[len(metaKeyPrefix):]
+		if err := fn(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get gets a value for a given key within the bucket.
+func (b *Bucket) Get(key []byte) ([]byte, error) {
+	return b.db.Get(prefixKey(b.id, key))
+}
+
+// Put saves a key-value entry within the bucket.
+func (b *Bucket) Put(key []byte, val []byte) error {
+	return b.db.Put(prefixKey(b.id, key), val)
+}
+
+// Delete deletes an entry given a key within the bucket.
+func (b *Bucket) Delete(key []byte) error {
+	return b.db.Delete(prefixKey(b.id, key))
+}
+
+// BucketCursor iterates over the entries of a single Bucket.
+type BucketCursor struct {
+	c      *Cursor
+	prefix []byte
+}
+
+// NewCursor returns a cursor scoped to this bucket: iteration only visits
+// keys under the bucket's prefix, skipping the keys of every other bucket.
+func (b *Bucket) NewCursor() (*BucketCursor, error) {
+	c, err := b.db.NewCursor()
+	if err != nil {
+		return nil, err
+	}
+	return &BucketCursor{c: c, prefix: bucketPrefix(b.id)}, nil
+}
+
+// Next advances the cursor to the next entry within the bucket, returning
+// false once there are no more entries belonging to this bucket. It does
+// not stop at the first non-matching key but scans to the end of the DB,
+// since MDBM is unordered and a bucket's keys are interleaved with every
+// other bucket's.
+func (bc *BucketCursor) Next() bool {
+	for bc.c.Next() {
+		if bytes.HasPrefix(bc.c.Key(), bc.prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the unprefixed key of the entry the cursor currently points
+// to.
+func (bc *BucketCursor) Key() []byte {
+	return bc.c.Key()[len(bc.prefix):]
+}
+
+// Value returns the value of the entry the cursor currently points to.
+func (bc *BucketCursor) Value() []byte {
+	return bc.c.Value()
+}
+
+// Close releases the underlying cursor's lock.
+func (bc *BucketCursor) Close() {
+	bc.c.Close()
+}
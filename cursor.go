@@ -0,0 +1,142 @@
+package mdbm
+
+/*
+
+#include "mdbm.h"
+
+// cgo ain't playin' nice with C macros
+void cursor_iter_init(MDBM_ITER* iter) {
+    MDBM_ITER_INIT(iter)
+}
+
+*/
+import "C"
+
+import (
+	"bytes"
+	"errors"
+	"unsafe"
+)
+
+// Cursor iterates over every entry in a DB. A Cursor owns its own
+// duplicated handle and iterator, so multiple goroutines can each hold a
+// Cursor and iterate simultaneously without stepping on each other.
+//
+// MDBM is unordered, so a Cursor visits entries in bucket/page order, not
+// key order.
+type Cursor struct {
+	db    *MDBM
+	iter  C.MDBM_ITER
+	entry C.kvpair
+	valid bool
+	open  bool
+}
+
+// NewCursor returns a Cursor over a duplicated handle of db, positioned
+// before the first entry. It holds a shared (reader) lock for its
+// lifetime, so other cursors and readers are not blocked while it is open.
+// Call Next to advance it.
+func (db *MDBM) NewCursor() (*Cursor, error) {
+	dup, err := db.Dup()
+	if err != nil {
+		return nil, errors.New("Cannot duplicate handle for cursor: " + err.Error())
+	}
+
+	c := &Cursor{db: dup}
+	C.cursor_iter_init(&c.iter)
+	if _, e := C.mdbm_lock_shared(dup.dbh); e != nil {
+		dup.Close()
+		return nil, errors.New("Cannot lock DB for cursor: " + e.Error())
+	}
+	c.open = true
+	return c, nil
+}
+
+// Next advances the cursor to the next entry, returning false once there
+// are no more entries.
+func (c *Cursor) Next() bool {
+	if !c.open {
+		return false
+	}
+	c.entry = C.mdbm_next_r(c.db.dbh, &c.iter)
+	c.valid = c.entry.key.dptr != nil && c.entry.key.dsize != 0
+	return c.valid
+}
+
+// Valid reports whether the cursor is currently positioned on an entry.
+func (c *Cursor) Valid() bool {
+	return c.valid
+}
+
+// Key returns the key of the entry the cursor currently points to.
+func (c *Cursor) Key() []byte {
+	k := c.entry.key
+	return C.GoBytes(unsafe.Pointer(k.dptr), k.dsize)
+}
+
+// Value returns the value of the entry the cursor currently points to.
+func (c *Cursor) Value() []byte {
+	v := c.entry.val
+	return C.GoBytes(unsafe.Pointer(v.dptr), v.dsize)
+}
+
+// Seek advances the cursor to the first entry whose key has the given
+// prefix, returning false if none is found. Because MDBM is unordered,
+// this is a full scan with client-side filtering, not a binary search.
+func (c *Cursor) Seek(prefix []byte) bool {
+	for c.Next() {
+		if bytes.HasPrefix(c.Key(), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close releases the cursor's lock and closes its duplicated handle. It
+// must always be called, including on early return from a loop over Next,
+// or the underlying lock is leaked.
+func (c *Cursor) Close() {
+	if !c.open {
+		return
+	}
+	C.mdbm_unlock(c.db.dbh)
+	c.open = false
+	c.db.Close()
+}
+
+// PrefixScan returns every key-value pair whose key has the given prefix.
+// MDBM is unordered, so this is a full scan filtered client-side.
+func (db *MDBM) PrefixScan(prefix []byte) ([]KV, error) {
+	c, err := db.NewCursor()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var results []KV
+	for c.Next() {
+		if bytes.HasPrefix(c.Key(), prefix) {
+			results = append(results, KV{Key: c.Key(), Val: c.Value()})
+		}
+	}
+	return results, nil
+}
+
+// RangeScan returns every key-value pair whose key is within [start, end).
+// MDBM is unordered, so this is a full scan filtered client-side.
+func (db *MDBM) RangeScan(start, end []byte) ([]KV, error) {
+	c, err := db.NewCursor()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var results []KV
+	for c.Next() {
+		k := c.Key()
+		if bytes.Compare(k, start) >= 0 && bytes.Compare(k, end) < 0 {
+			results = append(results, KV{Key: k, Val: c.Value()})
+		}
+	}
+	return results, nil
+}
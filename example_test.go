@@ -26,6 +26,7 @@ func Example() {
 	if err != nil {
 		log.Println("Cannot fetch value for \"key1\"", err.Error())
 	}
+	fmt.Println(string(val))
 
 	// Populate DB with entries
 	for i := 0; i < 1000000; i++ {
@@ -35,8 +36,15 @@ func Example() {
 	}
 
 	// Iterate through all entries in DB
-	for db.Fetch() {
-		k, v := db.Entry()
+	c, err := db.NewCursor()
+	if err != nil {
+		log.Fatal("Cannot create cursor: " + err.Error())
+	}
+	defer c.Close()
+
+	for c.Next() {
+		k, v := c.Key(), c.Value()
+		fmt.Printf("%s=%s\n", k, v)
 	}
 }
 
@@ -50,21 +58,161 @@ func ExampleMDBM_Open() {
 	defer db.Close()
 }
 
-func ExampleMDBM_Fetch() {
+func ExampleMDBM_Batch() {
 	db, err := mdbm.Open("my.db")
 	if err != nil {
 		log.Fatal("Cannot open my.db: " + err.Error())
 	}
 	defer db.Close()
 
-	// Iterate with db.Fetch()
-	for db.Fetch() {
-		_, v := db.Entry()
-		if string(v) == "127.0.0.1" {
-			log.Println("Localhost IP exists!")
+	// Store several entries under a single lock/unlock pass
+	b := db.NewBatch()
+	b.Put([]byte("key1"), []byte("val1"))
+	b.Put([]byte("key2"), []byte("val2"))
+	b.Delete([]byte("stale-key"))
+	if err := b.Commit(); err != nil {
+		log.Println("Cannot commit batch:", err.Error())
+	}
+
+	// Or hand over a slice of entries directly
+	err = db.Batch([]mdbm.KV{
+		{Key: []byte("key3"), Val: []byte("val3")},
+		{Key: []byte("key4"), Val: []byte("val4")},
+	})
+	if err != nil {
+		log.Println("Cannot store batch:", err.Error())
+	}
+}
+
+func ExampleMDBM_CoalesceBatch() {
+	db, err := mdbm.Open("my.db")
+	if err != nil {
+		log.Fatal("Cannot open my.db: " + err.Error())
+	}
+	defer db.Close()
+
+	// Concurrent goroutines submitting small batches are merged into a
+	// single flush after mdbm.DefaultMaxBatchDelay or mdbm.DefaultMaxBatchSize
+	// entries, whichever comes first.
+	err = db.CoalesceBatch([]mdbm.KV{
+		{Key: []byte("key1"), Val: []byte("val1")},
+	})
+	if err != nil {
+		log.Println("Cannot store batch:", err.Error())
+	}
+}
+
+func ExampleMDBM_Insert() {
+	db, err := mdbm.Open("my.db")
+	if err != nil {
+		log.Fatal("Cannot open my.db: " + err.Error())
+	}
+	defer db.Close()
+
+	if err := db.Insert([]byte("key1"), []byte("val1")); err != nil {
+		if err == mdbm.ErrKeyExists {
+			log.Println("\"key1\" already exists")
+		} else {
+			log.Println("Cannot insert \"key1\":", err.Error())
+		}
+	}
+
+	if err := db.Modify([]byte("key1"), []byte("val2")); err != nil {
+		if err == mdbm.ErrKeyNotFound {
+			log.Println("\"key1\" does not exist yet")
+		} else {
+			log.Println("Cannot modify \"key1\":", err.Error())
+		}
+	}
+
+	// Replace always succeeds; Upsert inserts or replaces as needed
+	db.Replace([]byte("key1"), []byte("val3"))
+	db.Upsert([]byte("key2"), []byte("val4"))
+
+	if db.Has([]byte("key1")) {
+		log.Println("\"key1\" exists!")
+	}
+}
+
+func ExampleMDBM_Snapshot() {
+	db, err := mdbm.Open("my.db")
+	if err != nil {
+		log.Fatal("Cannot open my.db: " + err.Error())
+	}
+	defer db.Close()
 
-			// Make sure to release the lock before break
-			db.Unlock()
+	// Take a consistent, point-in-time, read-only copy for an analytics job
+	// to scan without blocking writers or seeing partial updates.
+	snap, err := db.Snapshot()
+	if err != nil {
+		log.Fatal("Cannot take snapshot: " + err.Error())
+	}
+	defer snap.Close()
+
+	val, err := snap.Get([]byte("key1"))
+	if err != nil {
+		log.Println("Cannot fetch value for \"key1\"", err.Error())
+	}
+	fmt.Println(string(val))
+}
+
+func ExampleMDBM_CreateBucket() {
+	db, err := mdbm.Open("my.db")
+	if err != nil {
+		log.Fatal("Cannot open my.db: " + err.Error())
+	}
+	defer db.Close()
+
+	users, err := db.CreateBucket([]byte("users"))
+	if err != nil {
+		log.Fatal("Cannot create bucket: " + err.Error())
+	}
+
+	if err := users.Put([]byte("alice"), []byte("admin")); err != nil {
+		log.Println("Cannot store entry:", err.Error())
+	}
+
+	// Other buckets can reuse the same key without colliding
+	sessions := db.Bucket([]byte("sessions"))
+	if sessions == nil {
+		sessions, err = db.CreateBucket([]byte("sessions"))
+		if err != nil {
+			log.Fatal("Cannot create bucket: " + err.Error())
+		}
+	}
+	sessions.Put([]byte("alice"), []byte("token-123"))
+
+	c, err := users.NewCursor()
+	if err != nil {
+		log.Fatal("Cannot create bucket cursor: " + err.Error())
+	}
+	defer c.Close()
+
+	for c.Next() {
+		fmt.Printf("%s=%s\n", c.Key(), c.Value())
+	}
+
+	if err := db.DeleteBucket([]byte("sessions")); err != nil {
+		log.Println("Cannot delete bucket:", err.Error())
+	}
+}
+
+func ExampleMDBM_NewCursor() {
+	db, err := mdbm.Open("my.db")
+	if err != nil {
+		log.Fatal("Cannot open my.db: " + err.Error())
+	}
+	defer db.Close()
+
+	c, err := db.NewCursor()
+	if err != nil {
+		log.Fatal("Cannot create cursor: " + err.Error())
+	}
+	defer c.Close() // always releases the lock, even on early break
+
+	for c.Next() {
+		if string(c.Value()) == "127.0.0.1" {
+			log.Println("Localhost IP exists!")
 			break
 		}
 	}
@@ -9,11 +9,6 @@ package mdbm
 
 #include "mdbm.h"
 
-// cgo ain't playin' nice with C macros
-void mdbm_iter_init(MDBM_ITER* iter) {
-    MDBM_ITER_INIT(iter)
-}
-
 */
 import "C"
 
@@ -43,13 +38,14 @@ var (
 )
 
 type MDBM struct {
-	dbh                        *C.MDBM     // DB handle
-	iter                       C.MDBM_ITER // DB iterator
-	entry                      C.kvpair    // Last fetched entry w/ iter
-	hasLock                    bool        // Exclusive DB lock
-	flags, perms, psize, dsize int         // Options for openning DB
+	dbh                        *C.MDBM // DB handle
+	hasLock                    bool    // Exclusive DB lock
+	flags, perms, psize, dsize int     // Options for openning DB
 
 	mutex sync.Mutex
+
+	batchMu sync.Mutex
+	batch   *pendingBatch
 }
 
 type option func(*MDBM)
@@ -111,7 +107,6 @@ func Open(dbfile string, options ...option) (db *MDBM, err error) {
 		psize: 0,
 		dsize: 0,
 	}
-	C.mdbm_iter_init(&db.iter)
 
 	for _, opt := range options {
 		opt(db)
@@ -138,7 +133,6 @@ func (db *MDBM) Dup(options ...option) (dup *MDBM, err error) {
 		psize: db.psize,
 		dsize: db.dsize,
 	}
-	C.mdbm_iter_init(&dup.iter)
 
 	dup.dbh, err = C.mdbm_dup_handle(db.dbh, 0)
 	if err != nil {
@@ -240,26 +234,3 @@ func (db *MDBM) Unlock() error {
 	return nil
 }
 
-// Restart resets DB iterator
-func (db *MDBM) Restart() {
-	C.mdbm_iter_init(&db.iter)
-}
-
-// Fetch iterates through entries in DB for fetching. Each entry can be
-// retrieved by making a call to Entry().
-func (db *MDBM) Fetch() bool {
-	db.Lock()
-	db.entry = C.mdbm_next_r(db.dbh, &db.iter)
-	if db.entry.key.dptr != nil && db.entry.key.dsize != 0 {
-		return true
-	}
-	db.Unlock()
-	return false
-}
-
-// Entry returns the last fetched entry using an iterator by calling Fetch()
-func (db *MDBM) Entry() (key []byte, val []byte) {
-	k := db.entry.key
-	v := db.entry.val
-	return C.GoBytes(unsafe.Pointer(k.dptr), k.dsize), C.GoBytes(unsafe.Pointer(v.dptr), v.dsize)
-}
@@ -0,0 +1,76 @@
+package mdbm
+
+/*
+
+#include <stdlib.h>
+
+#include "mdbm.h"
+
+*/
+import "C"
+
+import (
+	"errors"
+	"os"
+)
+
+// Snapshot is a consistent, point-in-time, read-only copy of a DB, suitable
+// for analytics jobs that need to iterate without blocking writers or
+// seeing partial updates.
+type Snapshot struct {
+	db   *MDBM
+	path string
+}
+
+// Snapshot takes a consistent point-in-time copy of the DB: it locks db
+// exclusively, copies it out to a temp file with mdbm_fcopy, unlocks, and
+// opens the copy read-only. Callers must call Close on the returned
+// Snapshot to remove the temp file.
+func (db *MDBM) Snapshot() (*Snapshot, error) {
+	db.mutex.Lock()
+	if _, e := C.mdbm_lock(db.dbh); e != nil {
+		db.mutex.Unlock()
+		return nil, errors.New("Cannot lock DB for snapshot: " + e.Error())
+	}
+
+	f, err := os.CreateTemp("", "mdbm-snapshot-*.db")
+	if err != nil {
+		C.mdbm_unlock(db.dbh)
+		db.mutex.Unlock()
+		return nil, errors.New("Cannot create snapshot temp file: " + err.Error())
+	}
+	path := f.Name()
+
+	_, e := C.mdbm_fcopy(db.dbh, C.int(f.Fd()), 0)
+	f.Close()
+	C.mdbm_unlock(db.dbh)
+	db.mutex.Unlock()
+	if e != nil {
+		os.Remove(path)
+		return nil, errors.New("Cannot copy DB for snapshot: " + e.Error())
+	}
+
+	snap, err := Open(path, Flags(ReadOnly))
+	if err != nil {
+		os.Remove(path)
+		return nil, errors.New("Cannot open snapshot copy: " + err.Error())
+	}
+
+	return &Snapshot{db: snap, path: path}, nil
+}
+
+// Get gets a value for a given key from the snapshot.
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	return s.db.Get(key)
+}
+
+// NewCursor returns a Cursor over the snapshot.
+func (s *Snapshot) NewCursor() (*Cursor, error) {
+	return s.db.NewCursor()
+}
+
+// Close closes the snapshot's handle and removes its temp file.
+func (s *Snapshot) Close() error {
+	s.db.Close()
+	return os.Remove(s.path)
+}
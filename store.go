@@ -0,0 +1,87 @@
+package mdbm
+
+/*
+
+#include <errno.h>
+
+#include "mdbm.h"
+
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// ErrKeyExists is returned by Insert when the key is already present.
+var ErrKeyExists = errors.New("key already exists")
+
+// ErrKeyNotFound is returned by Modify when the key is not present.
+var ErrKeyNotFound = errors.New("key not found")
+
+func (db *MDBM) store(key []byte, val []byte, flag C.int) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	var k, v C.datum
+	k.dptr = (*C.char)(unsafe.Pointer(&key[0]))
+	k.dsize = C.int(len(key))
+	v.dptr = (*C.char)(unsafe.Pointer(&val[0]))
+	v.dsize = C.int(len(val))
+
+	C.mdbm_lock(db.dbh)
+	defer C.mdbm_unlock(db.dbh)
+	_, e := C.mdbm_store(db.dbh, k, v, flag)
+	if e == nil {
+		return nil
+	}
+	if flag == C.MDBM_INSERT && C.mdbm_get_errno(db.dbh) == C.EEXIST {
+		return ErrKeyExists
+	}
+	if flag == C.MDBM_MODIFY && C.mdbm_get_errno(db.dbh) == C.ENOENT {
+		return ErrKeyNotFound
+	}
+	return errors.New("Cannot store entry: " + e.Error())
+}
+
+// Insert saves a key-value entry, failing with ErrKeyExists if the key is
+// already present.
+func (db *MDBM) Insert(key []byte, val []byte) error {
+	return db.store(key, val, C.MDBM_INSERT)
+}
+
+// Modify updates a key-value entry, failing with ErrKeyNotFound if the key
+// does not already exist.
+func (db *MDBM) Modify(key []byte, val []byte) error {
+	return db.store(key, val, C.MDBM_MODIFY)
+}
+
+// Replace saves a key-value entry, overwriting any existing value for key.
+// It behaves the same as Put.
+func (db *MDBM) Replace(key []byte, val []byte) error {
+	return db.store(key, val, C.MDBM_REPLACE)
+}
+
+// Upsert saves a key-value entry, inserting it if the key is absent or
+// replacing it if present.
+func (db *MDBM) Upsert(key []byte, val []byte) error {
+	return db.store(key, val, C.MDBM_REPLACE)
+}
+
+// Has reports whether key is present in the DB. It fetches under a bounded
+// datum so the value is never copied into Go memory.
+func (db *MDBM) Has(key []byte) bool {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	var k C.datum
+	k.dptr = (*C.char)(unsafe.Pointer(&key[0]))
+	k.dsize = C.int(len(key))
+
+	C.mdbm_lock_smart(db.dbh, &k, 0)
+	defer C.mdbm_unlock_smart(db.dbh, &k, 0)
+
+	v := C.mdbm_fetch(db.dbh, k)
+	return v.dptr != nil
+}
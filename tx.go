@@ -0,0 +1,155 @@
+package mdbm
+
+/*
+
+#include "mdbm.h"
+
+// cgo ain't playin' nice with C macros
+void tx_iter_init(MDBM_ITER* iter) {
+    MDBM_ITER_INIT(iter)
+}
+
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// Tx represents an in-progress View or Update transaction against a
+// duplicated DB handle. Its methods must only be called from within the
+// closure passed to View or Update; the handle it wraps is closed as soon
+// as that closure returns.
+//
+// The transaction already holds the lock appropriate for its whole
+// duration (shared for View, exclusive for Update), so Tx's methods talk
+// to the underlying handle directly instead of going through
+// MDBM.Get/Put/Delete or NewCursor, which would each try to acquire their
+// own lock on top of one already held and hang.
+type Tx struct {
+	db       *MDBM
+	writable bool
+}
+
+// View opens a read-only transaction on a duplicated handle, holding a
+// shared lock on the DB for the duration of fn. The lock is released
+// automatically when fn returns, even if fn panics.
+func (db *MDBM) View(fn func(tx *Tx) error) error {
+	return db.runTx(false, fn)
+}
+
+// Update opens a read-write transaction on a duplicated handle, holding an
+// exclusive lock on the DB for the duration of fn. The lock is released
+// automatically when fn returns, even if fn panics.
+func (db *MDBM) Update(fn func(tx *Tx) error) error {
+	return db.runTx(true, fn)
+}
+
+func (db *MDBM) runTx(writable bool, fn func(tx *Tx) error) (err error) {
+	dup, e := db.Dup()
+	if e != nil {
+		return errors.New("Cannot duplicate handle for transaction: " + e.Error())
+	}
+	defer dup.Close()
+
+	if writable {
+		if _, e := C.mdbm_lock(dup.dbh); e != nil {
+			return errors.New("Cannot lock DB for transaction: " + e.Error())
+		}
+	} else {
+		if _, e := C.mdbm_lock_shared(dup.dbh); e != nil {
+			return errors.New("Cannot lock DB for transaction: " + e.Error())
+		}
+	}
+	defer C.mdbm_unlock(dup.dbh)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in transaction: %v", r)
+		}
+	}()
+
+	return fn(&Tx{db: dup, writable: writable})
+}
+
+// Writable reports whether tx was opened by Update.
+func (tx *Tx) Writable() bool {
+	return tx.writable
+}
+
+// Get gets a value for a given key. It talks directly to the handle
+// already locked by the enclosing View/Update, without acquiring any
+// further lock.
+func (tx *Tx) Get(key []byte) ([]byte, error) {
+	var k C.datum
+	k.dptr = (*C.char)(unsafe.Pointer(&key[0]))
+	k.dsize = C.int(len(key))
+
+	v, e := C.mdbm_fetch(tx.db.dbh, k)
+	if e != nil {
+		return nil, errors.New("Cannot retrieve entry: " + e.Error())
+	}
+	return C.GoBytes(unsafe.Pointer(v.dptr), v.dsize), nil
+}
+
+// Put saves a key-value entry. It returns an error if tx was opened by
+// View. Like Get, it operates on the already-locked handle directly.
+func (tx *Tx) Put(key []byte, val []byte) error {
+	if !tx.writable {
+		return errors.New("Cannot put entry: transaction is read-only")
+	}
+
+	var k, v C.datum
+	k.dptr = (*C.char)(unsafe.Pointer(&key[0]))
+	k.dsize = C.int(len(key))
+	v.dptr = (*C.char)(unsafe.Pointer(&val[0]))
+	v.dsize = C.int(len(val))
+
+	_, e := C.mdbm_store(tx.db.dbh, k, v, C.MDBM_REPLACE)
+	if e != nil {
+		return errors.New("Cannot store entry: " + e.Error())
+	}
+	return nil
+}
+
+// Delete deletes an entry given a key. It returns an error if tx was opened
+// by View. Like Get, it operates on the already-locked handle directly.
+func (tx *Tx) Delete(key []byte) error {
+	if !tx.writable {
+		return errors.New("Cannot delete entry: transaction is read-only")
+	}
+
+	var k C.datum
+	k.dptr = (*C.char)(unsafe.Pointer(&key[0]))
+	k.dsize = C.int(len(key))
+
+	_, e := C.mdbm_delete(tx.db.dbh, k)
+	if e != nil {
+		return errors.New("Cannot delete entry: " + e.Error())
+	}
+	return nil
+}
+
+// ForEach iterates through every entry in the DB, calling fn with each
+// key-value pair. Iteration stops at the first error returned by fn. It
+// uses its own MDBM_ITER directly against the already-locked handle rather
+// than NewCursor, which would try to lock the handle again.
+func (tx *Tx) ForEach(fn func(key, val []byte) error) error {
+	var iter C.MDBM_ITER
+	C.tx_iter_init(&iter)
+
+	for {
+		entry := C.mdbm_next_r(tx.db.dbh, &iter)
+		if entry.key.dptr == nil || entry.key.dsize == 0 {
+			return nil
+		}
+
+		k := C.GoBytes(unsafe.Pointer(entry.key.dptr), entry.key.dsize)
+		v := C.GoBytes(unsafe.Pointer(entry.val.dptr), entry.val.dsize)
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+}